@@ -0,0 +1,100 @@
+package osc94
+
+import (
+	"io"
+	"time"
+)
+
+// pendingUpdate is the latest (state, percent) pair awaiting flush.
+type pendingUpdate struct {
+	state   State
+	percent int
+}
+
+// WithAsyncFlush enables asynchronous output.
+//
+// Instead of writing on every Set call, Progress stores only the latest
+// (state, percent) pair and a single background goroutine flushes it to
+// the underlying writer at most once per interval. This bounds output
+// frequency for high-frequency callers without dropping the final
+// value. Call Close (or Stop) to flush any pending update and stop the
+// goroutine.
+//
+// A non-positive interval is replaced with the same ~100ms default used
+// by WithMinInterval, since time.NewTicker panics on a non-positive
+// duration and the flusher runs in a background goroutine where that
+// panic can't be recovered by the caller.
+func WithAsyncFlush(interval time.Duration) Option {
+	if interval <= 0 {
+		interval = defaultMinInterval
+	}
+
+	return func(progress *Progress) {
+		progress.async = true
+		progress.interval = interval
+	}
+}
+
+func (progress *Progress) startFlusher() {
+	progress.flushStop = make(chan struct{})
+	progress.flushDone = make(chan struct{})
+
+	go progress.flushLoop()
+}
+
+func (progress *Progress) flushLoop() {
+	defer close(progress.flushDone)
+
+	ticker := time.NewTicker(progress.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-progress.flushStop:
+			return
+		case <-ticker.C:
+			progress.flush()
+		}
+	}
+}
+
+// flush writes the latest pending update, if any, and clears it so the
+// same value isn't written again on the next tick.
+func (progress *Progress) flush() error {
+	update := progress.pending.Swap(nil)
+	if update == nil {
+		return nil
+	}
+
+	escape, err := escapeWithTerminator(update.state, update.percent, progress.terminator)
+	if err != nil {
+		return err
+	}
+
+	progress.mu.Lock()
+	defer progress.mu.Unlock()
+
+	_, err = io.WriteString(progress.writer, escape)
+	return err
+}
+
+// Close flushes any pending asynchronous update and stops the background
+// flusher started by WithAsyncFlush. It is a no-op if WithAsyncFlush was
+// not used. Close is idempotent and safe to call more than once.
+func (progress *Progress) Close() error {
+	if !progress.async {
+		return nil
+	}
+
+	progress.closeOnce.Do(func() {
+		close(progress.flushStop)
+		<-progress.flushDone
+	})
+
+	return progress.flush()
+}
+
+// Stop is an alias for Close.
+func (progress *Progress) Stop() error {
+	return progress.Close()
+}
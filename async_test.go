@@ -0,0 +1,100 @@
+package osc94
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestProgressSetIsConcurrencySafe(t *testing.T) {
+	var buffer bytes.Buffer
+	progress := New(&buffer, WithEnabled(true))
+
+	const goroutines = 50
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(percent int) {
+			defer wg.Done()
+			progress.SetPercent(percent % 101)
+		}(i)
+	}
+	wg.Wait()
+
+	if got := strings.Count(buffer.String(), "\x1b]9;4;1;"); got != goroutines {
+		t.Fatalf("Set() wrote %d complete escapes, want %d (output: %q)", got, goroutines, buffer.String())
+	}
+}
+
+func TestWithAsyncFlushCoalescesUpdates(t *testing.T) {
+	var buffer bytes.Buffer
+	progress := New(&buffer, WithEnabled(true), WithAsyncFlush(10*time.Millisecond))
+
+	for i := 0; i <= 100; i++ {
+		if err := progress.SetPercent(i); err != nil {
+			t.Fatalf("SetPercent() error = %v", err)
+		}
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if err := progress.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	want := "\x1b]9;4;1;100\a"
+	if got := buffer.String(); got != want {
+		t.Fatalf("flusher wrote %q, want %q", got, want)
+	}
+}
+
+func TestWithAsyncFlushNonPositiveIntervalDoesNotPanic(t *testing.T) {
+	var buffer bytes.Buffer
+	progress := New(&buffer, WithEnabled(true), WithAsyncFlush(0))
+	defer progress.Close()
+
+	if err := progress.SetPercent(10); err != nil {
+		t.Fatalf("SetPercent() error = %v", err)
+	}
+}
+
+func TestProgressCloseFlushesPendingUpdate(t *testing.T) {
+	var buffer bytes.Buffer
+	progress := New(&buffer, WithEnabled(true), WithAsyncFlush(time.Hour))
+
+	if err := progress.SetPercent(42); err != nil {
+		t.Fatalf("SetPercent() error = %v", err)
+	}
+
+	if err := progress.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	want := "\x1b]9;4;1;42\a"
+	if got := buffer.String(); got != want {
+		t.Fatalf("Close() wrote %q, want %q", got, want)
+	}
+}
+
+func TestProgressCloseIsIdempotent(t *testing.T) {
+	var buffer bytes.Buffer
+	progress := New(&buffer, WithEnabled(true), WithAsyncFlush(time.Hour))
+
+	if err := progress.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := progress.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+}
+
+func TestProgressCloseNoopWithoutAsync(t *testing.T) {
+	var buffer bytes.Buffer
+	progress := New(&buffer, WithEnabled(true))
+
+	if err := progress.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
@@ -0,0 +1,189 @@
+package osc94
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultMinInterval           = 100 * time.Millisecond
+	defaultIndeterminateInterval = 500 * time.Millisecond
+)
+
+// Bar is a high-level progress indicator built on top of Progress.
+//
+// It tracks a running total internally so callers can report progress by
+// adding items or bytes processed instead of computing percentages by
+// hand. Updates are throttled (see WithMinInterval) so tight loops don't
+// flood the terminal with escape sequences.
+type Bar struct {
+	progress    *Progress
+	minInterval time.Duration
+
+	mu        sync.Mutex
+	total     int64
+	current   int64
+	lastWrite time.Time
+
+	indeterminate bool
+	stop          chan struct{}
+	done          chan struct{}
+}
+
+// BarOption configures a Bar.
+type BarOption func(*Bar)
+
+// WithMinInterval sets the minimum duration between emitted updates.
+//
+// The default is 100ms. A zero interval disables throttling.
+func WithMinInterval(interval time.Duration) BarOption {
+	return func(bar *Bar) {
+		bar.minInterval = interval
+	}
+}
+
+// NewBar returns a Bar that reports progress toward total.
+//
+// A total of 0 or less starts the bar in Indeterminate mode. Such a bar
+// has no total to measure against, so Add and SetCurrent never emit an
+// update, before or after Finish or Fail; only Finish and Fail do.
+func (progress *Progress) NewBar(total int64, opts ...BarOption) *Bar {
+	bar := &Bar{
+		progress:    progress,
+		total:       total,
+		minInterval: defaultMinInterval,
+	}
+
+	for _, opt := range opts {
+		opt(bar)
+	}
+
+	if total <= 0 {
+		bar.Indeterminate()
+	}
+
+	return bar
+}
+
+// Add increments the current total by n and emits a throttled update.
+func (bar *Bar) Add(n int64) error {
+	bar.mu.Lock()
+	bar.current += n
+	current := bar.current
+	bar.mu.Unlock()
+
+	return bar.emit(current)
+}
+
+// SetCurrent sets the current total and emits a throttled update.
+func (bar *Bar) SetCurrent(n int64) error {
+	bar.mu.Lock()
+	bar.current = n
+	bar.mu.Unlock()
+
+	return bar.emit(n)
+}
+
+// Indeterminate switches the bar to indeterminate mode and starts a
+// background ticker that periodically re-emits the OSC 9;4 sequence, so
+// terminals that clear stale state after a timeout keep showing activity.
+func (bar *Bar) Indeterminate() error {
+	bar.mu.Lock()
+	if bar.indeterminate {
+		bar.mu.Unlock()
+		return nil
+	}
+	bar.indeterminate = true
+	bar.stop = make(chan struct{})
+	bar.done = make(chan struct{})
+	bar.mu.Unlock()
+
+	err := bar.progress.Indeterminate()
+
+	go bar.tick()
+
+	return err
+}
+
+// Finish marks the bar complete, stopping any indeterminate ticker and
+// emitting a final 100% update.
+func (bar *Bar) Finish() error {
+	bar.stopTicker()
+	return bar.progress.SetPercent(maxPercent)
+}
+
+// Fail marks the bar failed, stopping any indeterminate ticker and
+// emitting a StateError update at the current percentage.
+func (bar *Bar) Fail() error {
+	bar.stopTicker()
+
+	bar.mu.Lock()
+	current, total := bar.current, bar.total
+	bar.mu.Unlock()
+
+	return bar.progress.Error(percentOf(current, total))
+}
+
+func (bar *Bar) emit(current int64) error {
+	bar.mu.Lock()
+	if bar.total <= 0 {
+		bar.mu.Unlock()
+		return nil
+	}
+
+	now := time.Now()
+	if bar.minInterval > 0 && !bar.lastWrite.IsZero() && now.Sub(bar.lastWrite) < bar.minInterval {
+		bar.mu.Unlock()
+		return nil
+	}
+	bar.lastWrite = now
+	total := bar.total
+	bar.mu.Unlock()
+
+	return bar.progress.SetPercent(percentOf(current, total))
+}
+
+func (bar *Bar) tick() {
+	defer close(bar.done)
+
+	ticker := time.NewTicker(defaultIndeterminateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-bar.stop:
+			return
+		case <-ticker.C:
+			bar.progress.Indeterminate()
+		}
+	}
+}
+
+func (bar *Bar) stopTicker() {
+	bar.mu.Lock()
+	if !bar.indeterminate {
+		bar.mu.Unlock()
+		return
+	}
+	bar.indeterminate = false
+	stop, done := bar.stop, bar.done
+	bar.mu.Unlock()
+
+	close(stop)
+	<-done
+}
+
+func percentOf(current, total int64) int {
+	if total <= 0 {
+		return minPercent
+	}
+
+	percent := int(current * 100 / total)
+	if percent < minPercent {
+		return minPercent
+	}
+	if percent > maxPercent {
+		return maxPercent
+	}
+	return percent
+}
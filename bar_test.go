@@ -0,0 +1,114 @@
+package osc94
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestBarAddEmitsThrottledUpdates(t *testing.T) {
+	var buffer bytes.Buffer
+	progress := New(&buffer, WithEnabled(true))
+	bar := progress.NewBar(200, WithMinInterval(time.Hour))
+
+	if err := bar.Add(100); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	want := "\x1b]9;4;1;50\a"
+	if got := buffer.String(); got != want {
+		t.Fatalf("Add() wrote %q, want %q", got, want)
+	}
+
+	buffer.Reset()
+	if err := bar.Add(50); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if got := buffer.String(); got != "" {
+		t.Fatalf("Add() wrote %q while throttled, want empty", got)
+	}
+}
+
+func TestBarSetCurrentNoThrottle(t *testing.T) {
+	var buffer bytes.Buffer
+	progress := New(&buffer, WithEnabled(true))
+	bar := progress.NewBar(10, WithMinInterval(0))
+
+	if err := bar.SetCurrent(5); err != nil {
+		t.Fatalf("SetCurrent() error = %v", err)
+	}
+	want := "\x1b]9;4;1;50\a"
+	if got := buffer.String(); got != want {
+		t.Fatalf("SetCurrent() wrote %q, want %q", got, want)
+	}
+}
+
+func TestBarFinish(t *testing.T) {
+	var buffer bytes.Buffer
+	progress := New(&buffer, WithEnabled(true))
+	bar := progress.NewBar(10, WithMinInterval(0))
+
+	if err := bar.Finish(); err != nil {
+		t.Fatalf("Finish() error = %v", err)
+	}
+	want := "\x1b]9;4;1;100\a"
+	if got := buffer.String(); got != want {
+		t.Fatalf("Finish() wrote %q, want %q", got, want)
+	}
+}
+
+func TestBarFail(t *testing.T) {
+	var buffer bytes.Buffer
+	progress := New(&buffer, WithEnabled(true))
+	bar := progress.NewBar(10, WithMinInterval(0))
+
+	if err := bar.SetCurrent(3); err != nil {
+		t.Fatalf("SetCurrent() error = %v", err)
+	}
+
+	buffer.Reset()
+	if err := bar.Fail(); err != nil {
+		t.Fatalf("Fail() error = %v", err)
+	}
+	want := "\x1b]9;4;2;30\a"
+	if got := buffer.String(); got != want {
+		t.Fatalf("Fail() wrote %q, want %q", got, want)
+	}
+}
+
+func TestBarIndeterminateEmitsAndStops(t *testing.T) {
+	var buffer bytes.Buffer
+	progress := New(&buffer, WithEnabled(true))
+	bar := progress.NewBar(0)
+
+	want := "\x1b]9;4;3;0\a"
+	if got := buffer.String(); got != want {
+		t.Fatalf("NewBar() wrote %q, want %q", got, want)
+	}
+
+	if err := bar.Finish(); err != nil {
+		t.Fatalf("Finish() error = %v", err)
+	}
+}
+
+func TestPercentOf(t *testing.T) {
+	tests := map[string]struct {
+		current int64
+		total   int64
+		want    int
+	}{
+		"zero_total":  {current: 5, total: 0, want: 0},
+		"half":        {current: 50, total: 100, want: 50},
+		"clamp_low":   {current: -10, total: 100, want: 0},
+		"clamp_high":  {current: 150, total: 100, want: 100},
+		"exact_total": {current: 100, total: 100, want: 100},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			if got := percentOf(tc.current, tc.total); got != tc.want {
+				t.Fatalf("percentOf() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
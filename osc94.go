@@ -10,6 +10,9 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 const (
@@ -39,10 +42,21 @@ const (
 type terminator int
 
 // Progress writes OSC 9;4 sequences to an output stream.
+//
+// Writes are synchronized, so a Progress may be shared across goroutines.
 type Progress struct {
 	writer     io.Writer
 	enabled    bool
 	terminator terminator
+
+	mu sync.Mutex
+
+	async     bool
+	interval  time.Duration
+	pending   atomic.Pointer[pendingUpdate]
+	flushStop chan struct{}
+	flushDone chan struct{}
+	closeOnce sync.Once
 }
 
 // Option configures a Progress instance.
@@ -62,6 +76,10 @@ func New(writer io.Writer, opts ...Option) *Progress {
 		opt(progress)
 	}
 
+	if progress.async {
+		progress.startFlusher()
+	}
+
 	return progress
 }
 
@@ -102,7 +120,10 @@ func WithTerminatorST() Option {
 
 // Set writes a progress update using the provided state and percentage.
 //
-// Percent must be 0-100 unless state is StateIndeterminate.
+// Percent must be 0-100 unless state is StateIndeterminate. If
+// WithAsyncFlush is in effect, Set only records the update; the
+// background flusher writes it out, coalescing any updates that arrive
+// within the same interval.
 func (progress *Progress) Set(state State, percent int) error {
 	if !progress.enabled {
 		return nil
@@ -113,6 +134,14 @@ func (progress *Progress) Set(state State, percent int) error {
 		return err
 	}
 
+	if progress.async {
+		progress.pending.Store(&pendingUpdate{state: state, percent: percent})
+		return nil
+	}
+
+	progress.mu.Lock()
+	defer progress.mu.Unlock()
+
 	_, err = io.WriteString(progress.writer, escape)
 	return err
 }
@@ -213,21 +242,6 @@ func terminatorSequence(seqTerminator terminator) (string, error) {
 	}
 }
 
-// isTTY returns true when the writer is a character device.
-func isTTY(writer io.Writer) bool {
-	file, ok := writer.(*os.File)
-	if !ok {
-		return false
-	}
-
-	info, err := file.Stat()
-	if err != nil {
-		return false
-	}
-
-	return info.Mode()&os.ModeCharDevice != 0
-}
-
 // isDumbTerm reports whether TERM indicates a basic terminal.
 func isDumbTerm() bool {
 	term := strings.TrimSpace(os.Getenv("TERM"))
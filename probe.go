@@ -0,0 +1,139 @@
+package osc94
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// probeQuery requests Primary Device Attributes (CSI c) and XTVERSION
+// (CSI > q) in a single write, so a single read captures whichever the
+// emulator answers with.
+const probeQuery = "\x1b[c\x1b[>q"
+
+// Emulator identifies a terminal emulator recognized by ProbeSupport.
+type Emulator string
+
+// Known emulators that implement OSC 9;4.
+const (
+	EmulatorUnknown         Emulator = ""
+	EmulatorWindowsTerminal Emulator = "Windows Terminal"
+	EmulatorITerm2          Emulator = "iTerm2"
+	EmulatorVTE             Emulator = "VTE"
+	EmulatorWezTerm         Emulator = "WezTerm"
+	EmulatorGhostty         Emulator = "Ghostty"
+	EmulatorConEmu          Emulator = "ConEmu"
+)
+
+// emulatorHints maps substrings found in a DA1/XTVERSION response to the
+// emulator that produces them.
+var emulatorHints = map[string]Emulator{
+	"WezTerm":          EmulatorWezTerm,
+	"ghostty":          EmulatorGhostty,
+	"iTerm2":           EmulatorITerm2,
+	"VTE":              EmulatorVTE,
+	"ConEmu":           EmulatorConEmu,
+	"Windows Terminal": EmulatorWindowsTerminal,
+}
+
+// errNoReadDeadline is returned by readProbeResponse when rw doesn't
+// support SetReadDeadline. ProbeSupport surfaces it as an error rather
+// than folding it into the "unsupported" result, since without a
+// deadline the Read below could block forever.
+var errNoReadDeadline = errors.New("osc94: rw does not support SetReadDeadline, cannot probe without risking a blocked read")
+
+// ProbeSupport actively probes rw for OSC 9;4 support.
+//
+// rw must support SetReadDeadline (as *os.File and most terminal/pty
+// connections do); otherwise ProbeSupport returns an error rather than
+// risking a Read that blocks forever on a terminal that never answers.
+//
+// If rw is a terminal, ProbeSupport puts it into raw mode for the
+// duration of the probe, sends a Primary Device Attributes query and an
+// XTVERSION query, and matches the response against a table of terminal
+// emulators known to implement OSC 9;4. A timeout or an unrecognized
+// response is reported as unsupported rather than an error, since many
+// terminals simply stay silent on these queries.
+func ProbeSupport(rw io.ReadWriter, timeout time.Duration) (bool, error) {
+	if file, ok := rw.(*os.File); ok && IsTerminal(file) {
+		state, err := term.MakeRaw(int(file.Fd()))
+		if err != nil {
+			return false, fmt.Errorf("osc94: enter raw mode: %w", err)
+		}
+		defer term.Restore(int(file.Fd()), state)
+	}
+
+	if _, err := io.WriteString(rw, probeQuery); err != nil {
+		return false, fmt.Errorf("osc94: write probe query: %w", err)
+	}
+
+	response, err := readProbeResponse(rw, timeout)
+	if err != nil {
+		if errors.Is(err, errNoReadDeadline) {
+			return false, err
+		}
+		return false, nil
+	}
+
+	return identifyEmulator(response) != EmulatorUnknown, nil
+}
+
+// deadliner is implemented by *os.File and other connections that
+// support bounding a Read in time.
+type deadliner interface {
+	SetReadDeadline(time.Time) error
+}
+
+func readProbeResponse(rw io.ReadWriter, timeout time.Duration) (string, error) {
+	d, ok := rw.(deadliner)
+	if !ok {
+		return "", errNoReadDeadline
+	}
+
+	if err := d.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return "", err
+	}
+	defer d.SetReadDeadline(time.Time{})
+
+	buf := make([]byte, 256)
+	n, err := rw.Read(buf)
+	if err != nil {
+		return "", err
+	}
+	return string(buf[:n]), nil
+}
+
+func identifyEmulator(response string) Emulator {
+	for hint, emulator := range emulatorHints {
+		if strings.Contains(response, hint) {
+			return emulator
+		}
+	}
+	return EmulatorUnknown
+}
+
+// WithAutoProbe enables output only when an active ProbeSupport check
+// succeeds within timeout.
+//
+// Unlike WithAutoEnable, which relies on environment variable hints,
+// WithAutoProbe queries the terminal directly and so needs writer to
+// also support reads (an *os.File opened for read/write, typically the
+// process's own stdout). If writer isn't readable, it behaves like
+// WithEnabled(false).
+func WithAutoProbe(timeout time.Duration) Option {
+	return func(progress *Progress) {
+		rw, ok := progress.writer.(io.ReadWriter)
+		if !ok {
+			progress.enabled = false
+			return
+		}
+
+		supported, err := ProbeSupport(rw, timeout)
+		progress.enabled = err == nil && supported
+	}
+}
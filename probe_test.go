@@ -0,0 +1,119 @@
+package osc94
+
+import (
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+// probeConn is a fake deadliner: it honors SetReadDeadline itself so
+// tests can exercise the timeout path without Read blocking forever.
+type probeConn struct {
+	written  []byte
+	reply    []byte
+	block    chan struct{}
+	deadline time.Time
+}
+
+func (c *probeConn) Write(p []byte) (int, error) {
+	c.written = append(c.written, p...)
+	return len(p), nil
+}
+
+func (c *probeConn) SetReadDeadline(t time.Time) error {
+	c.deadline = t
+	return nil
+}
+
+func (c *probeConn) Read(p []byte) (int, error) {
+	if len(c.reply) > 0 {
+		n := copy(p, c.reply)
+		c.reply = c.reply[n:]
+		return n, nil
+	}
+
+	var deadline <-chan time.Time
+	if !c.deadline.IsZero() {
+		deadline = time.After(time.Until(c.deadline))
+	}
+
+	select {
+	case <-c.block:
+		return 0, io.EOF
+	case <-deadline:
+		return 0, os.ErrDeadlineExceeded
+	}
+}
+
+func TestProbeSupportRecognizesKnownEmulator(t *testing.T) {
+	conn := &probeConn{reply: []byte("\x1bP>|WezTerm 20230712\x1b\\")}
+
+	supported, err := ProbeSupport(conn, time.Second)
+	if err != nil {
+		t.Fatalf("ProbeSupport() error = %v", err)
+	}
+	if !supported {
+		t.Fatalf("ProbeSupport() = false, want true")
+	}
+	if string(conn.written) != probeQuery {
+		t.Fatalf("ProbeSupport() wrote %q, want %q", conn.written, probeQuery)
+	}
+}
+
+func TestProbeSupportUnknownResponse(t *testing.T) {
+	conn := &probeConn{reply: []byte("\x1b[?1;2c")}
+
+	supported, err := ProbeSupport(conn, time.Second)
+	if err != nil {
+		t.Fatalf("ProbeSupport() error = %v", err)
+	}
+	if supported {
+		t.Fatalf("ProbeSupport() = true, want false")
+	}
+}
+
+func TestProbeSupportTimeout(t *testing.T) {
+	conn := &probeConn{block: make(chan struct{})}
+
+	supported, err := ProbeSupport(conn, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("ProbeSupport() error = %v", err)
+	}
+	if supported {
+		t.Fatalf("ProbeSupport() = true, want false on timeout")
+	}
+}
+
+type nonDeadlineConn struct{}
+
+func (nonDeadlineConn) Write(p []byte) (int, error) { return len(p), nil }
+func (nonDeadlineConn) Read([]byte) (int, error)    { select {} }
+
+func TestProbeSupportRejectsNonDeadlineReadWriter(t *testing.T) {
+	_, err := ProbeSupport(nonDeadlineConn{}, 10*time.Millisecond)
+	if err == nil {
+		t.Fatalf("ProbeSupport() expected error for a ReadWriter without SetReadDeadline")
+	}
+}
+
+func TestIdentifyEmulator(t *testing.T) {
+	tests := map[string]struct {
+		response string
+		want     Emulator
+	}{
+		"wezterm": {response: "\x1bP>|WezTerm 20230712\x1b\\", want: EmulatorWezTerm},
+		"iterm2":  {response: "\x1bP>|iTerm2 3.4.0\x1b\\", want: EmulatorITerm2},
+		"unknown": {response: "\x1b[?1;2c", want: EmulatorUnknown},
+		"conemu":  {response: "\x1bP>|ConEmu 220715\x1b\\", want: EmulatorConEmu},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			if got := identifyEmulator(tc.response); got != tc.want {
+				t.Fatalf("identifyEmulator() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package osc94
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// InstallSignalHandler spawns a goroutine that clears progress's
+// indicator when the process receives one of signals. If no signals are
+// given, it listens for os.Interrupt and syscall.SIGTERM.
+//
+// Plain Progress has no notion of "how far along" a transfer is, so this
+// clears the indicator rather than reporting an error state. Callers
+// tracking a running total with Bar should use
+// InstallSignalHandlerForBar instead, so a signal mid-transfer reports
+// StateError at the point it stopped instead of silently clearing to 0.
+//
+// Terminals such as Windows Terminal keep the last-set OSC 9;4 state
+// until it's explicitly cleared, so a CLI killed by Ctrl-C would
+// otherwise leave a stuck taskbar indicator. The goroutine stops when
+// ctx is done or a listened-for signal arrives, whichever comes first.
+func InstallSignalHandler(ctx context.Context, progress *Progress, signals ...os.Signal) {
+	installSignalHandler(ctx, signals, func() {
+		progress.Clear()
+	})
+}
+
+// InstallSignalHandlerForBar is like InstallSignalHandler, but reports
+// StateError at bar's current percentage instead of clearing to 0, so a
+// signal arriving partway through a transfer doesn't look like nothing
+// was ever in progress.
+func InstallSignalHandlerForBar(ctx context.Context, bar *Bar, signals ...os.Signal) {
+	installSignalHandler(ctx, signals, func() {
+		bar.Fail()
+	})
+}
+
+func installSignalHandler(ctx context.Context, signals []os.Signal, onSignal func()) {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, signals...)
+
+	go func() {
+		defer signal.Stop(ch)
+
+		select {
+		case <-ctx.Done():
+		case <-ch:
+			onSignal()
+		}
+	}()
+}
+
+// GuardPanic recovers from a panic, emits StateError, and re-panics.
+//
+// Call it as a deferred statement, e.g. `defer progress.GuardPanic()` in
+// main, so a crashing CLI doesn't leave a stuck taskbar progress
+// indicator behind for the user.
+func (progress *Progress) GuardPanic() {
+	if r := recover(); r != nil {
+		progress.Error(minPercent)
+		panic(r)
+	}
+}
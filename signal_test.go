@@ -0,0 +1,143 @@
+package osc94
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer guards bytes.Buffer so a test goroutine can safely read
+// while the package's own internal goroutine writes concurrently.
+type syncBuffer struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.String()
+}
+
+func (b *syncBuffer) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buffer.Reset()
+}
+
+func (b *syncBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.Len()
+}
+
+func TestInstallSignalHandlerClearsOnSignal(t *testing.T) {
+	buffer := &syncBuffer{}
+	progress := New(buffer, WithEnabled(true))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	InstallSignalHandler(ctx, progress, os.Interrupt)
+
+	if err := progress.SetPercent(50); err != nil {
+		t.Fatalf("SetPercent() error = %v", err)
+	}
+	buffer.Reset()
+
+	process, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatalf("os.FindProcess() error = %v", err)
+	}
+	if err := process.Signal(os.Interrupt); err != nil {
+		t.Fatalf("failed to signal self: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for buffer.Len() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	want := "\x1b]9;4;0;0\a"
+	if got := buffer.String(); got != want {
+		t.Fatalf("InstallSignalHandler() wrote %q, want %q", got, want)
+	}
+}
+
+func TestInstallSignalHandlerStopsOnContextDone(t *testing.T) {
+	var buffer bytes.Buffer
+	progress := New(&buffer, WithEnabled(true))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	InstallSignalHandler(ctx, progress, os.Interrupt)
+	cancel()
+
+	time.Sleep(10 * time.Millisecond)
+	if buffer.Len() != 0 {
+		t.Fatalf("InstallSignalHandler() wrote %q after context cancellation, want empty", buffer.String())
+	}
+}
+
+func TestInstallSignalHandlerForBarReportsErrorAtCurrentPercent(t *testing.T) {
+	buffer := &syncBuffer{}
+	progress := New(buffer, WithEnabled(true))
+	bar := progress.NewBar(200, WithMinInterval(0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	InstallSignalHandlerForBar(ctx, bar, os.Interrupt)
+
+	if err := bar.SetCurrent(80); err != nil {
+		t.Fatalf("SetCurrent() error = %v", err)
+	}
+	buffer.Reset()
+
+	process, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatalf("os.FindProcess() error = %v", err)
+	}
+	if err := process.Signal(os.Interrupt); err != nil {
+		t.Fatalf("failed to signal self: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for buffer.Len() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	want := "\x1b]9;4;2;40\a"
+	if got := buffer.String(); got != want {
+		t.Fatalf("InstallSignalHandlerForBar() wrote %q, want %q", got, want)
+	}
+}
+
+func TestGuardPanicEmitsErrorAndRepanics(t *testing.T) {
+	var buffer bytes.Buffer
+	progress := New(&buffer, WithEnabled(true))
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatalf("expected GuardPanic to re-panic")
+			}
+		}()
+		defer progress.GuardPanic()
+
+		panic("boom")
+	}()
+
+	want := "\x1b]9;4;2;0\a"
+	if got := buffer.String(); got != want {
+		t.Fatalf("GuardPanic() wrote %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,85 @@
+package osc94
+
+import "io"
+
+// progressReader wraps an io.Reader and reports progress as bytes are read.
+type progressReader struct {
+	reader   io.Reader
+	progress *Progress
+	total    int64
+	read     int64
+}
+
+// WrapReader decorates r so that reads report progress toward total via
+// progress as they happen.
+//
+// The returned ReadCloser clears the indicator on Close. A read error
+// other than io.EOF switches the indicator to StateError before it is
+// returned to the caller. If r implements io.Closer, Close also closes r.
+func (progress *Progress) WrapReader(r io.Reader, total int64) io.ReadCloser {
+	return &progressReader{reader: r, progress: progress, total: total}
+}
+
+func (pr *progressReader) Read(p []byte) (int, error) {
+	n, err := pr.reader.Read(p)
+	if n > 0 {
+		pr.read += int64(n)
+		pr.progress.SetPercent(percentOf(pr.read, pr.total))
+	}
+
+	if err != nil && err != io.EOF {
+		pr.progress.Error(percentOf(pr.read, pr.total))
+	}
+
+	return n, err
+}
+
+func (pr *progressReader) Close() error {
+	pr.progress.Clear()
+
+	if closer, ok := pr.reader.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// progressWriter wraps an io.Writer and reports progress as bytes are written.
+type progressWriter struct {
+	writer   io.Writer
+	progress *Progress
+	total    int64
+	written  int64
+}
+
+// WrapWriter decorates w so that writes report progress toward total via
+// progress as they happen.
+//
+// The returned WriteCloser clears the indicator on Close. A write error
+// switches the indicator to StateError before it is returned to the
+// caller. If w implements io.Closer, Close also closes w.
+func (progress *Progress) WrapWriter(w io.Writer, total int64) io.WriteCloser {
+	return &progressWriter{writer: w, progress: progress, total: total}
+}
+
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	n, err := pw.writer.Write(p)
+	if n > 0 {
+		pw.written += int64(n)
+		pw.progress.SetPercent(percentOf(pw.written, pw.total))
+	}
+
+	if err != nil {
+		pw.progress.Error(percentOf(pw.written, pw.total))
+	}
+
+	return n, err
+}
+
+func (pw *progressWriter) Close() error {
+	pw.progress.Clear()
+
+	if closer, ok := pw.writer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package osc94
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWrapReaderReportsProgress(t *testing.T) {
+	var out bytes.Buffer
+	progress := New(&out, WithEnabled(true))
+
+	src := strings.NewReader("hello world")
+	reader := progress.WrapReader(src, src.Size())
+
+	buf := make([]byte, 4)
+	if _, err := reader.Read(buf); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	want := "\x1b]9;4;1;36\a"
+	if got := out.String(); got != want {
+		t.Fatalf("Read() wrote %q, want %q", got, want)
+	}
+
+	out.Reset()
+	if err := reader.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got := out.String(); got != "\x1b]9;4;0;0\a" {
+		t.Fatalf("Close() wrote %q, want clear", got)
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("boom")
+}
+
+func TestWrapReaderErrorSwitchesState(t *testing.T) {
+	var out bytes.Buffer
+	progress := New(&out, WithEnabled(true))
+
+	reader := progress.WrapReader(errReader{}, 10)
+
+	buf := make([]byte, 4)
+	if _, err := reader.Read(buf); err == nil {
+		t.Fatalf("Read() expected error")
+	}
+
+	want := "\x1b]9;4;2;0\a"
+	if got := out.String(); got != want {
+		t.Fatalf("Read() wrote %q, want %q", got, want)
+	}
+}
+
+func TestWrapWriterReportsProgress(t *testing.T) {
+	var out bytes.Buffer
+	progress := New(&out, WithEnabled(true))
+
+	var dest bytes.Buffer
+	writer := progress.WrapWriter(&dest, 10)
+
+	if _, err := io.WriteString(writer, "01234"); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	want := "\x1b]9;4;1;50\a"
+	if got := out.String(); got != want {
+		t.Fatalf("Write() wrote %q, want %q", got, want)
+	}
+
+	out.Reset()
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got := out.String(); got != "\x1b]9;4;0;0\a" {
+		t.Fatalf("Close() wrote %q, want clear", got)
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("boom")
+}
+
+func TestWrapWriterErrorSwitchesState(t *testing.T) {
+	var out bytes.Buffer
+	progress := New(&out, WithEnabled(true))
+
+	writer := progress.WrapWriter(errWriter{}, 10)
+
+	if _, err := writer.Write([]byte("x")); err == nil {
+		t.Fatalf("Write() expected error")
+	}
+
+	want := "\x1b]9;4;2;0\a"
+	if got := out.String(); got != want {
+		t.Fatalf("Write() wrote %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,32 @@
+package osc94
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// IsTerminal reports whether writer is connected to a terminal.
+//
+// Unlike a raw os.ModeCharDevice check, this also recognizes terminals
+// behind Windows ConPTY, ConEmu, and MSYS/Cygwin pipes, which don't
+// present as character devices the way a native console does.
+func IsTerminal(writer io.Writer) bool {
+	file, ok := writer.(*os.File)
+	if !ok {
+		return false
+	}
+
+	if term.IsTerminal(int(file.Fd())) {
+		return true
+	}
+
+	return isMSYSPipe(file)
+}
+
+// isTTY is kept as the default ttyCheck for detect; it delegates to the
+// exported IsTerminal so callers of Detect and IsTerminal agree.
+func isTTY(writer io.Writer) bool {
+	return IsTerminal(writer)
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package osc94
+
+import "os"
+
+// isMSYSPipe is a no-op outside Windows, where MSYS/Cygwin pipes don't
+// exist.
+func isMSYSPipe(*os.File) bool {
+	return false
+}
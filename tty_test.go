@@ -0,0 +1,27 @@
+package osc94
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestIsTerminalNonFile(t *testing.T) {
+	var buffer bytes.Buffer
+	if IsTerminal(&buffer) {
+		t.Fatalf("IsTerminal() = true, want false for non-*os.File writer")
+	}
+}
+
+func TestIsTerminalPipe(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+	defer reader.Close()
+	defer writer.Close()
+
+	if IsTerminal(writer) {
+		t.Fatalf("IsTerminal() = true, want false for a plain pipe")
+	}
+}
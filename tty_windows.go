@@ -0,0 +1,42 @@
+//go:build windows
+
+package osc94
+
+import (
+	"os"
+	"regexp"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var msysPipeNamePattern = regexp.MustCompile(`\\(cygwin|msys)-[0-9a-f]+-pty[0-9]+-(to|from)-master`)
+
+// isMSYSPipe recognizes MSYS/Cygwin pty pipes.
+//
+// These present as regular named pipes rather than console handles, so
+// term.IsTerminal alone reports them as non-terminals even when a real
+// pty is attached on the other end, as happens under Git Bash and
+// similar MSYS2/Cygwin environments.
+func isMSYSPipe(file *os.File) bool {
+	handle := windows.Handle(file.Fd())
+
+	fileType, err := windows.GetFileType(handle)
+	if err != nil || fileType != windows.FILE_TYPE_PIPE {
+		return false
+	}
+
+	var buf [windows.MAX_PATH]uint16
+	err = windows.GetFileInformationByHandleEx(
+		handle,
+		windows.FileNameInfo,
+		(*byte)(unsafe.Pointer(&buf[0])),
+		uint32(len(buf)*2),
+	)
+	if err != nil {
+		return false
+	}
+
+	name := windows.UTF16ToString(buf[2:])
+	return msysPipeNamePattern.MatchString(name)
+}